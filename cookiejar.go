@@ -0,0 +1,255 @@
+package requests4go
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// setDefaultJar returns the default, public-suffix-aware in-memory cookie
+// jar given to every Client this package creates itself, and installed by
+// Session.LoadCookies when the session's Client has none. A Client supplied
+// by the caller without a Jar is left without one.
+func setDefaultJar() http.CookieJar {
+	jar, _ := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
+	return jar
+}
+
+// cookiesFromMap converts a map of name/value pairs, as used by
+// RequestArguments.Cookies, into []*http.Cookie.
+func cookiesFromMap(m map[string]string) []*http.Cookie {
+	cookies := make([]*http.Cookie, 0, len(m))
+	for k, v := range m {
+		cookies = append(cookies, &http.Cookie{Name: k, Value: v})
+	}
+	return cookies
+}
+
+// hostAllowed reports whether host may receive the cookies carried on a
+// request. An empty allow-list permits every host.
+func hostAllowed(allowed map[string]struct{}, host string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	_, ok := allowed[host]
+	return ok
+}
+
+// addCheckRedirectLimit sets args.Client.CheckRedirect to follow at most
+// args.RedirectLimit redirects and, when args.AllowedHosts is set, to stop
+// following a redirect whose target host is outside it. Returning
+// http.ErrUseLastResponse from CheckRedirect makes the Client hand back
+// the redirect response itself instead of following it, so a disallowed
+// hop never gets a chance to pick up cookies from the jar.
+func addCheckRedirectLimit(args *RequestArguments) {
+	limit := args.RedirectLimit
+	allowed := args.AllowedHosts
+
+	args.Client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if !hostAllowed(allowed, req.URL.Host) {
+			return http.ErrUseLastResponse
+		}
+		if limit > 0 && len(via) >= limit {
+			return fmt.Errorf("addCheckRedirectLimit error: stopped after %d redirects", limit)
+		}
+		return nil
+	}
+}
+
+// persistedCookie is the on-disk representation used by Session.SaveCookies
+// and Session.LoadCookies.
+type persistedCookie struct {
+	Host     string    `json:"host"`
+	Name     string    `json:"name"`
+	Value    string    `json:"value"`
+	Path     string    `json:"path"`
+	Domain   string    `json:"domain"`
+	Expires  time.Time `json:"expires"`
+	Secure   bool      `json:"secure"`
+	HTTPOnly bool      `json:"http_only"`
+}
+
+// SetAllowedHosts restricts the session to the given hosts: requests made
+// through the session will not carry args.Cookies to a host outside the
+// list, and addCheckRedirectLimit stops the session's Client from
+// following a redirect that would leave the list, so a redirect hop can
+// never pick up cookies for a host the session didn't explicitly allow.
+// An empty list removes the restriction, which is the default.
+func (s *Session) SetAllowedHosts(hosts []string) {
+	s.allowedHosts = make(map[string]struct{}, len(hosts))
+	for _, h := range hosts {
+		s.allowedHosts[h] = struct{}{}
+	}
+}
+
+// SaveCookies writes the session's cookies to path. Files ending in
+// ".txt" are written in the Netscape cookies.txt format; any other
+// extension is written as JSON.
+func (s *Session) SaveCookies(path string) error {
+	jar := s.Client.Jar
+	if jar == nil {
+		return fmt.Errorf("Session.SaveCookies error: session has no cookie jar")
+	}
+
+	var all []persistedCookie
+	for _, u := range s.knownURLs {
+		for _, c := range jar.Cookies(u) {
+			all = append(all, persistedCookie{
+				Host:     u.Host,
+				Name:     c.Name,
+				Value:    c.Value,
+				Path:     c.Path,
+				Domain:   c.Domain,
+				Expires:  c.Expires,
+				Secure:   c.Secure,
+				HTTPOnly: c.HttpOnly,
+			})
+		}
+	}
+
+	var data []byte
+	var err error
+	if strings.HasSuffix(path, ".txt") {
+		data = []byte(encodeNetscapeCookies(all))
+	} else {
+		data, err = json.MarshalIndent(all, "", "  ")
+		if err != nil {
+			return fmt.Errorf("Session.SaveCookies error: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("Session.SaveCookies error: %w", err)
+	}
+	return nil
+}
+
+// LoadCookies reads cookies previously written by SaveCookies from path
+// and installs them into the session's cookie jar, creating a default
+// jar if the session doesn't have one yet.
+func (s *Session) LoadCookies(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Session.LoadCookies error: %w", err)
+	}
+
+	var all []persistedCookie
+	if strings.HasSuffix(path, ".txt") {
+		all, err = decodeNetscapeCookies(data)
+	} else {
+		err = json.Unmarshal(data, &all)
+	}
+	if err != nil {
+		return fmt.Errorf("Session.LoadCookies error: %w", err)
+	}
+
+	if s.Client.Jar == nil {
+		s.Client.Jar = setDefaultJar()
+	}
+
+	byHost := make(map[string][]*http.Cookie)
+	for _, pc := range all {
+		byHost[pc.Host] = append(byHost[pc.Host], &http.Cookie{
+			Name:     pc.Name,
+			Value:    pc.Value,
+			Path:     pc.Path,
+			Domain:   pc.Domain,
+			Expires:  pc.Expires,
+			Secure:   pc.Secure,
+			HttpOnly: pc.HTTPOnly,
+		})
+	}
+
+	for host, cookies := range byHost {
+		u := &url.URL{Scheme: "https", Host: host}
+		s.Client.Jar.SetCookies(u, cookies)
+		s.trackURL(u)
+	}
+	return nil
+}
+
+// encodeNetscapeCookies renders cookies using the Netscape cookies.txt
+// format understood by curl and most browsers.
+func encodeNetscapeCookies(cookies []persistedCookie) string {
+	var b strings.Builder
+	b.WriteString("# Netscape HTTP Cookie File\n")
+	for _, c := range cookies {
+		domain := c.Domain
+		if domain == "" {
+			domain = c.Host
+		}
+		includeSubdomains := "FALSE"
+		if strings.HasPrefix(domain, ".") {
+			includeSubdomains = "TRUE"
+		}
+		path := c.Path
+		if path == "" {
+			path = "/"
+		}
+		var expires int64
+		if !c.Expires.IsZero() {
+			expires = c.Expires.Unix()
+		}
+		fmt.Fprintf(&b, "%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			domain, includeSubdomains, path, strconv.FormatBool(c.Secure), expires, c.Name, c.Value)
+	}
+	return b.String()
+}
+
+// decodeNetscapeCookies parses the Netscape cookies.txt format.
+func decodeNetscapeCookies(data []byte) ([]persistedCookie, error) {
+	var cookies []persistedCookie
+
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) != 7 {
+			return nil, fmt.Errorf("decodeNetscapeCookies error: malformed line %q", line)
+		}
+
+		secure, err := strconv.ParseBool(fields[3])
+		if err != nil {
+			return nil, fmt.Errorf("decodeNetscapeCookies error: %w", err)
+		}
+
+		expiresUnix, err := strconv.ParseInt(fields[4], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("decodeNetscapeCookies error: %w", err)
+		}
+
+		var expires time.Time
+		if expiresUnix != 0 {
+			expires = time.Unix(expiresUnix, 0)
+		}
+
+		domain := fields[0]
+		cookies = append(cookies, persistedCookie{
+			Host:    strings.TrimPrefix(domain, "."),
+			Domain:  domain,
+			Path:    fields[2],
+			Secure:  secure,
+			Expires: expires,
+			Name:    fields[5],
+			Value:   fields[6],
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("decodeNetscapeCookies error: %w", err)
+	}
+	return cookies, nil
+}
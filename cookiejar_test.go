@@ -0,0 +1,87 @@
+package requests4go
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustParseURL(t *testing.T, raw string) *url.URL {
+	t.Helper()
+	u, err := url.Parse(raw)
+	assert.Nil(t, err)
+	return u
+}
+
+func TestAddCheckRedirectLimitStopsDisallowedHost(t *testing.T) {
+	args := NewRequestArguments()
+	args.AllowedHosts = map[string]struct{}{"allowed.example": {}}
+	addCheckRedirectLimit(args)
+
+	req := httptest.NewRequest(http.MethodGet, "http://blocked.example/path", nil)
+	err := args.Client.CheckRedirect(req, nil)
+	assert.Equal(t, http.ErrUseLastResponse, err)
+
+	req = httptest.NewRequest(http.MethodGet, "http://allowed.example/path", nil)
+	err = args.Client.CheckRedirect(req, nil)
+	assert.Nil(t, err)
+}
+
+func TestAddCheckRedirectLimitStopsAfterLimit(t *testing.T) {
+	args := NewRequestArguments()
+	args.RedirectLimit = 2
+	addCheckRedirectLimit(args)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/path", nil)
+	via := []*http.Request{req, req}
+	err := args.Client.CheckRedirect(req, via)
+	assert.NotNil(t, err)
+}
+
+func TestSessionSaveAndLoadCookiesJSON(t *testing.T) {
+	s := NewSession(nil)
+	s.trackURL(mustParseURL(t, "https://example.com"))
+	s.Client.Jar.SetCookies(mustParseURL(t, "https://example.com"), []*http.Cookie{
+		{Name: "a", Value: "1"},
+	})
+
+	path := filepath.Join(t.TempDir(), "cookies.json")
+	assert.Nil(t, s.SaveCookies(path))
+
+	s2 := NewSession(nil)
+	assert.Nil(t, s2.LoadCookies(path))
+
+	cookies := s2.Client.Jar.Cookies(mustParseURL(t, "https://example.com"))
+	assert.Equal(t, 1, len(cookies))
+	assert.Equal(t, "a", cookies[0].Name)
+	assert.Equal(t, "1", cookies[0].Value)
+}
+
+func TestSessionSaveAndLoadCookiesNetscape(t *testing.T) {
+	s := NewSession(nil)
+	s.trackURL(mustParseURL(t, "https://example.com"))
+	s.Client.Jar.SetCookies(mustParseURL(t, "https://example.com"), []*http.Cookie{
+		{Name: "a", Value: "1", Path: "/"},
+	})
+
+	path := filepath.Join(t.TempDir(), "cookies.txt")
+	assert.Nil(t, s.SaveCookies(path))
+
+	data, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), "# Netscape HTTP Cookie File")
+	assert.Contains(t, string(data), "a\t1")
+
+	s2 := NewSession(nil)
+	assert.Nil(t, s2.LoadCookies(path))
+
+	cookies := s2.Client.Jar.Cookies(mustParseURL(t, "https://example.com"))
+	assert.Equal(t, 1, len(cookies))
+	assert.Equal(t, "a", cookies[0].Name)
+	assert.Equal(t, "1", cookies[0].Value)
+}
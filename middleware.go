@@ -0,0 +1,61 @@
+package requests4go
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Handler sends an *http.Request and returns the resulting *http.Response,
+// mirroring the signature of http.Client.Do so it can wrap or replace it.
+type Handler func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps a Handler with cross-cutting concerns such as logging,
+// tracing, metrics, auth token refresh, request signing or rate limiting.
+type Middleware func(next Handler) Handler
+
+// chain composes middlewares around base. The first middleware in the
+// slice is the outermost one: it runs first on the way in and last on the
+// way out.
+func chain(base Handler, middlewares []Middleware) Handler {
+	h := base
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+	return h
+}
+
+// buildHandler returns the Handler sendRequest uses to perform a request:
+// args.Client.Do wrapped by args.Middlewares.
+func buildHandler(args *RequestArguments) Handler {
+	return chain(args.Client.Do, args.Middlewares)
+}
+
+// BearerAuth returns a Middleware that sets the Authorization header to
+// "Bearer <token>" on every request passing through it.
+func BearerAuth(token string) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("Authorization", "Bearer "+token)
+			return next(req)
+		}
+	}
+}
+
+// SignFunc computes a request signature to attach to outgoing requests,
+// e.g. an HMAC over the method, path and body.
+type SignFunc func(req *http.Request) (header, value string, err error)
+
+// Signing returns a Middleware that calls sign for every request and sets
+// the returned header to the returned value before forwarding the request.
+func Signing(sign SignFunc) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			header, value, err := sign(req)
+			if err != nil {
+				return nil, fmt.Errorf("Signing middleware error: %w", err)
+			}
+			req.Header.Set(header, value)
+			return next(req)
+		}
+	}
+}
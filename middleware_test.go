@@ -0,0 +1,52 @@
+package requests4go
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainOrdering(t *testing.T) {
+	var order []string
+
+	mark := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(req *http.Request) (*http.Response, error) {
+				order = append(order, name+":in")
+				resp, err := next(req)
+				order = append(order, name+":out")
+				return resp, err
+			}
+		}
+	}
+
+	base := Handler(func(req *http.Request) (*http.Response, error) {
+		order = append(order, "base")
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+
+	h := chain(base, []Middleware{mark("outer"), mark("inner")})
+	_, err := h(newTestRequest())
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"outer:in", "inner:in", "base", "inner:out", "outer:out"}, order)
+}
+
+func TestBearerAuthSetsHeader(t *testing.T) {
+	var gotAuth string
+	base := Handler(func(req *http.Request) (*http.Response, error) {
+		gotAuth = req.Header.Get("Authorization")
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+
+	h := chain(base, []Middleware{BearerAuth("secret-token")})
+	_, err := h(newTestRequest())
+	assert.Nil(t, err)
+	assert.Equal(t, "Bearer secret-token", gotAuth)
+}
+
+func newTestRequest() *http.Request {
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	return req
+}
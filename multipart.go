@@ -0,0 +1,211 @@
+package requests4go
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"path/filepath"
+)
+
+// ProgressFunc reports upload progress as the files in RequestArguments.Files
+// are copied into the multipart body. written is the number of file bytes
+// copied so far across all Files entries; total is their combined size, or
+// -1 if it could not be determined in advance. It does not count Data
+// fields or multipart encoding overhead (boundaries, part headers), so
+// when total is known, written never exceeds it.
+type ProgressFunc func(written, total int64)
+
+// FileFieldFromPath opens the file at path and returns a FileField ready
+// for RequestArguments.Files, using the base name of path as both the
+// form field name and the file name.
+func FileFieldFromPath(path string) (FileField, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return FileField{}, fmt.Errorf("FileFieldFromPath error: %w", err)
+	}
+
+	name := filepath.Base(path)
+	return FileField{
+		FieldName:   name,
+		FileName:    name,
+		FileContent: f,
+	}, nil
+}
+
+// MultipartForm builds the Files and Data needed to send fields as a
+// multipart/form-data body from a single map: an entry backed by an
+// *os.File becomes a file part named after its base name, everything else
+// is read fully and sent as a Data field. The result is meant to be
+// assigned to RequestArguments.Files/Data, so it streams through
+// prepareFilesBody and honors RequestArguments.Progress like any other
+// Files upload.
+func MultipartForm(fields map[string]io.Reader) ([]FileField, map[string]string, error) {
+	var files []FileField
+	data := make(map[string]string)
+
+	for name, r := range fields {
+		if f, ok := r.(*os.File); ok {
+			files = append(files, FileField{
+				FieldName:   name,
+				FileName:    filepath.Base(f.Name()),
+				FileContent: f,
+			})
+			continue
+		}
+
+		content, err := io.ReadAll(r)
+		if err != nil {
+			return nil, nil, fmt.Errorf("MultipartForm error: %w", err)
+		}
+		data[name] = string(content)
+	}
+
+	return files, data, nil
+}
+
+// prepareFilesBody prepares the body for a multipart/form-data request.
+// It streams the multipart encoding through an io.Pipe instead of
+// buffering it fully in memory, so multi-GB uploads don't OOM, reporting
+// progress through progress if non-nil. It returns body, contentType and
+// error.
+func prepareFilesBody(files []FileField, data map[string]string, progress ProgressFunc) (io.Reader, string, error) {
+	pr, pw := io.Pipe()
+
+	writer := multipart.NewWriter(pw)
+	contentType := writer.FormDataContentType()
+	cw := newFilesProgressWriter(files, progress)
+
+	go func() {
+		if err := writeMultipartBody(writer, files, data, cw); err != nil {
+			_ = pw.CloseWithError(err)
+			return
+		}
+		_ = pw.Close()
+	}()
+
+	return pr, contentType, nil
+}
+
+// prepareFilesBodyBuffered is like prepareFilesBody, but builds the whole
+// multipart body into memory up front instead of streaming it. It is used
+// instead of prepareFilesBody when RequestArguments.Retry is set: a piped
+// body can only be read once, so req.GetBody is always nil and a retried
+// request fails with "request body is not replayable"; a *bytes.Buffer
+// body is one of the types http.NewRequest auto-populates GetBody for, so
+// retrying a multipart request works the same way it does for a JSON or
+// Data body.
+func prepareFilesBodyBuffered(files []FileField, data map[string]string, progress ProgressFunc) (io.Reader, string, error) {
+	buf := &bytes.Buffer{}
+	writer := multipart.NewWriter(buf)
+	contentType := writer.FormDataContentType()
+	cw := newFilesProgressWriter(files, progress)
+
+	if err := writeMultipartBody(writer, files, data, cw); err != nil {
+		return nil, "", err
+	}
+
+	return buf, contentType, nil
+}
+
+// newFilesProgressWriter returns the countingWriter writeMultipartBody
+// should report file bytes through, or nil if progress is nil.
+func newFilesProgressWriter(files []FileField, progress ProgressFunc) *countingWriter {
+	if progress == nil {
+		return nil
+	}
+
+	total, ok := totalFilesSize(files)
+	if !ok {
+		total = -1
+	}
+
+	cw := &countingWriter{}
+	cw.onWrite = func() { progress(cw.written, total) }
+	return cw
+}
+
+// writeMultipartBody writes files, in the order given, and then data as
+// multipart/form-data parts through writer, closing writer once done. The
+// data fields follow map iteration order, which is not fixed. When cw is
+// non-nil, every file's content is copied through it so its onWrite
+// callback sees the running total of file bytes written.
+func writeMultipartBody(writer *multipart.Writer, files []FileField, data map[string]string, cw *countingWriter) error {
+	for _, file := range files {
+		var fileWriter io.Writer
+		var err error
+
+		if file.ContentType != "" {
+			header := make(textproto.MIMEHeader)
+			header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, file.FieldName, file.FileName))
+			header.Set("Content-Type", file.ContentType)
+			fileWriter, err = writer.CreatePart(header)
+		} else {
+			fileWriter, err = writer.CreateFormFile(file.FieldName, file.FileName)
+		}
+		if err != nil {
+			return fmt.Errorf("prepareFilesBody error: %w", err)
+		}
+
+		dst := fileWriter
+		if cw != nil {
+			cw.w = fileWriter
+			dst = cw
+		}
+		if _, err := io.Copy(dst, file.FileContent); err != nil {
+			return fmt.Errorf("prepareFilesBody error: %w", err)
+		}
+
+		if err := file.FileContent.Close(); err != nil {
+			return fmt.Errorf("prepareFilesBody error: %w", err)
+		}
+	}
+
+	for key, value := range data {
+		if err := writer.WriteField(key, value); err != nil {
+			return fmt.Errorf("prepareFilesBody error: %w", err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("prepareFilesBody error: %w", err)
+	}
+	return nil
+}
+
+// totalFilesSize sums the size of every file's content when all of them
+// are backed by *os.File, so the total body size can be reported to a
+// ProgressFunc. It reports ok=false if any file's size is unknown.
+func totalFilesSize(files []FileField) (total int64, ok bool) {
+	for _, f := range files {
+		file, isFile := f.FileContent.(*os.File)
+		if !isFile {
+			return 0, false
+		}
+		info, err := file.Stat()
+		if err != nil {
+			return 0, false
+		}
+		total += info.Size()
+	}
+	return total, true
+}
+
+// countingWriter wraps an io.Writer, tracking the number of bytes written
+// through it and invoking onWrite after each write.
+type countingWriter struct {
+	w       io.Writer
+	written int64
+	onWrite func()
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.written += int64(n)
+	if c.onWrite != nil {
+		c.onWrite()
+	}
+	return n, err
+}
@@ -0,0 +1,121 @@
+package requests4go
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTempFile(t *testing.T, content string) *os.File {
+	t.Helper()
+	f, err := os.CreateTemp(t.TempDir(), "multipart-test-*")
+	assert.Nil(t, err)
+	_, err = f.WriteString(content)
+	assert.Nil(t, err)
+	assert.Nil(t, f.Close())
+
+	f, err = os.Open(f.Name())
+	assert.Nil(t, err)
+	return f
+}
+
+func TestProgressNeverExceedsTotal(t *testing.T) {
+	files := []FileField{
+		{FieldName: "a", FileName: "a.txt", FileContent: writeTempFile(t, strings.Repeat("a", 100))},
+		{FieldName: "b", FileName: "b.txt", FileContent: writeTempFile(t, strings.Repeat("b", 200))},
+	}
+
+	var lastWritten, lastTotal int64
+	progress := func(written, total int64) {
+		assert.LessOrEqual(t, written, total)
+		lastWritten, lastTotal = written, total
+	}
+
+	body, _, err := prepareFilesBody(files, map[string]string{"field": "value"}, progress)
+	assert.Nil(t, err)
+
+	_, err = io.Copy(io.Discard, body)
+	assert.Nil(t, err)
+
+	assert.Equal(t, int64(300), lastTotal)
+	assert.Equal(t, lastTotal, lastWritten)
+}
+
+func TestPrepareFilesBodyBufferedReplayable(t *testing.T) {
+	files := []FileField{
+		{FieldName: "file", FileName: "file.txt", FileContent: writeTempFile(t, "file content")},
+	}
+
+	body, contentType, err := prepareFilesBodyBuffered(files, map[string]string{"key": "value"}, nil)
+	assert.Nil(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, "http://example.com", body)
+	assert.Nil(t, err)
+	req.Header.Set("Content-Type", contentType)
+
+	assert.NotNil(t, req.GetBody)
+
+	first, err := io.ReadAll(req.Body)
+	assert.Nil(t, err)
+
+	replay, err := req.GetBody()
+	assert.Nil(t, err)
+	second, err := io.ReadAll(replay)
+	assert.Nil(t, err)
+
+	assert.Equal(t, first, second)
+}
+
+func TestMultipartFormBuildsFilesAndData(t *testing.T) {
+	f := writeTempFile(t, "file content")
+	fields := map[string]io.Reader{
+		"file":  f,
+		"field": strings.NewReader("value"),
+	}
+
+	files, data, err := MultipartForm(fields)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 1, len(files))
+	assert.Equal(t, "file", files[0].FieldName)
+	assert.Equal(t, "value", data["field"])
+}
+
+func TestSendRequestWithRetryReplaysMultipartFiles(t *testing.T) {
+	var calls int32
+	var bodies []string
+	var mu sync.Mutex
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, string(body))
+		mu.Unlock()
+
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	args := NewRequestArguments()
+	args.Files = []FileField{{FieldName: "file", FileName: "file.txt", FileContent: writeTempFile(t, "hello retry")}}
+	args.Retry = &RetryPolicy{MaxAttempts: 2, BackoffBase: time.Millisecond, BackoffCap: time.Millisecond}
+
+	resp, err := Post(srv.URL, args)
+	assert.Nil(t, err)
+	assert.True(t, resp.Ok())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.Equal(t, 2, len(bodies))
+	assert.Equal(t, bodies[0], bodies[1])
+}
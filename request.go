@@ -2,10 +2,10 @@ package requests4go
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"mime/multipart"
 	"net/http"
 	"net/url"
 	"strings"
@@ -21,6 +21,10 @@ type FileField struct {
 	// FieldName specifies form field name.
 	FieldName string
 
+	// ContentType, when set, is sent as the part's Content-Type; otherwise
+	// the part is sent as application/octet-stream.
+	ContentType string
+
 	FileContent io.ReadCloser
 }
 
@@ -59,7 +63,12 @@ type RequestArguments struct {
 	// into the body of Post request.
 	Data map[string]string
 
-	// Files specifies the files you wish to post.
+	// Files specifies the files you wish to post. The multipart body is
+	// normally streamed rather than buffered, so an error produced while
+	// reading a file surfaces as a transport error on the HTTP
+	// round-trip rather than synchronously from prepareRequest. When
+	// Retry is also set, the body is buffered in memory instead so it
+	// can be replayed between attempts.
 	Files []FileField
 
 	// RedirectLimit specifies the how many times we can
@@ -68,10 +77,41 @@ type RequestArguments struct {
 
 	// Timeout specifies a time limit for requests made by Client of
 	// RequestArguments. The timeout includes connection time, any
-	// redirects, and reading the response body.
+	// redirects, and reading the response body. When Retry is set,
+	// Timeout instead bounds the whole retry loop (every attempt and
+	// backoff wait combined), rather than each attempt individually.
 	//
 	// If Timeout is zero, it means no timeout.
 	Timeout time.Duration
+
+	// Retry specifies the RetryPolicy used to replay the request when it
+	// fails with a transient network error or a retriable status code.
+	//
+	// If Retry is nil, the request is attempted exactly once.
+	Retry *RetryPolicy
+
+	// Middlewares wraps the request pipeline with cross-cutting concerns
+	// such as logging, tracing, metrics, auth token refresh, request
+	// signing or rate limiting. The first Middleware in the slice is the
+	// outermost one.
+	Middlewares []Middleware
+
+	// Context, when set, is attached to the outgoing http.Request via
+	// req.WithContext and is checked by the retry subsystem, so canceling
+	// it aborts an in-flight request or a pending retry wait.
+	Context context.Context
+
+	// AllowedHosts, when non-empty, restricts which request host may
+	// receive Cookies, and which redirect hops addCheckRedirectLimit lets
+	// the Client follow. It is populated by Session.SetAllowedHosts and
+	// normally left nil for one-off requests.
+	AllowedHosts map[string]struct{}
+
+	// Progress, when set, is called as the content of Files is copied into
+	// the multipart body. The body is normally streamed, so this tracks the
+	// upload itself; when Retry is set the body is built in memory first,
+	// so every call happens before the request is sent.
+	Progress ProgressFunc
 }
 
 // NewRequestArguments returns a new default RequestArguments object.
@@ -80,7 +120,7 @@ func NewRequestArguments() *RequestArguments {
 		Client: &http.Client{
 			Jar: setDefaultJar(),
 		},
-		Headers:       defaultHeaders,
+		Headers:       cloneDefaultHeaders(),
 		RedirectLimit: defaultRedirectLimit,
 	}
 }
@@ -88,13 +128,7 @@ func NewRequestArguments() *RequestArguments {
 // sendRequest sends http request and returns the response.
 func sendRequest(method, reqUrl string, args *RequestArguments) (*Response, error) {
 	if args == nil {
-		args = &RequestArguments{
-			Client: &http.Client{
-				Jar: setDefaultJar(),
-			},
-			Headers:       defaultHeaders,
-			RedirectLimit: defaultRedirectLimit,
-		}
+		args = NewRequestArguments()
 	}
 
 	if args.Client == nil {
@@ -103,7 +137,10 @@ func sendRequest(method, reqUrl string, args *RequestArguments) (*Response, erro
 		}
 	}
 
-	if args.Timeout != 0 {
+	// When Retry is set, args.Timeout is a total budget shared across every
+	// attempt, enforced by sendRequestWithRetry via a context deadline, not
+	// a per-attempt http.Client.Timeout.
+	if args.Timeout != 0 && args.Retry == nil {
 		args.Client.Timeout = args.Timeout
 	}
 
@@ -115,7 +152,76 @@ func sendRequest(method, reqUrl string, args *RequestArguments) (*Response, erro
 		return nil, fmt.Errorf("sendRequest error: %w", err)
 	}
 
-	return NewResponse(args.Client.Do(req))
+	if args.Context != nil {
+		req = req.WithContext(args.Context)
+	}
+
+	if args.Retry != nil {
+		return sendRequestWithRetry(req, args)
+	}
+
+	return NewResponse(buildHandler(args)(req))
+}
+
+// NewRequest sends a request of the given method.
+func NewRequest(method, reqUrl string, args *RequestArguments) (*Response, error) {
+	return sendRequest(method, reqUrl, args)
+}
+
+// Get sends a GET request.
+func Get(reqUrl string, args *RequestArguments) (*Response, error) {
+	return sendRequest(http.MethodGet, reqUrl, args)
+}
+
+// Post sends a POST request.
+func Post(reqUrl string, args *RequestArguments) (*Response, error) {
+	return sendRequest(http.MethodPost, reqUrl, args)
+}
+
+// Put sends a PUT request.
+func Put(reqUrl string, args *RequestArguments) (*Response, error) {
+	return sendRequest(http.MethodPut, reqUrl, args)
+}
+
+// Delete sends a DELETE request.
+func Delete(reqUrl string, args *RequestArguments) (*Response, error) {
+	return sendRequest(http.MethodDelete, reqUrl, args)
+}
+
+// Patch sends a PATCH request.
+func Patch(reqUrl string, args *RequestArguments) (*Response, error) {
+	return sendRequest(http.MethodPatch, reqUrl, args)
+}
+
+// Head sends a HEAD request.
+func Head(reqUrl string, args *RequestArguments) (*Response, error) {
+	return sendRequest(http.MethodHead, reqUrl, args)
+}
+
+// sendRequestWithContext is like sendRequest, but binds the request to
+// ctx: the request is canceled, and any retry wait aborted, as soon as
+// ctx is done.
+func sendRequestWithContext(ctx context.Context, method, reqUrl string, args *RequestArguments) (*Response, error) {
+	if args == nil {
+		args = NewRequestArguments()
+	}
+	args.Context = ctx
+	return sendRequest(method, reqUrl, args)
+}
+
+// NewRequestWithContext sends a request of the given method bound to ctx.
+func NewRequestWithContext(ctx context.Context, method, reqUrl string, args *RequestArguments) (*Response, error) {
+	return sendRequestWithContext(ctx, method, reqUrl, args)
+}
+
+// GetWithContext sends a GET request bound to ctx.
+func GetWithContext(ctx context.Context, reqUrl string, args *RequestArguments) (*Response, error) {
+	return sendRequestWithContext(ctx, http.MethodGet, reqUrl, args)
+}
+
+// PostWithContext sends a POST request bound to ctx.
+func PostWithContext(ctx context.Context, reqUrl string, args *RequestArguments) (*Response, error) {
+	return sendRequestWithContext(ctx, http.MethodPost, reqUrl, args)
 }
 
 // prepareRequest prepares http.Request according to method, url and RequestArguments.
@@ -159,6 +265,9 @@ func prepareCookies(args *RequestArguments, req *http.Request) {
 	if args.CookieJar != nil {
 		args.Client.Jar = args.CookieJar
 	} else if args.Cookies != nil {
+		if !hostAllowed(args.AllowedHosts, req.URL.Host) {
+			return
+		}
 		cookies := args.Client.Jar.Cookies(req.URL)
 		cusCookie := cookiesFromMap(args.Cookies)
 		cookies = append(cookies, cusCookie...)
@@ -178,7 +287,14 @@ func prepareBody(args *RequestArguments) (io.Reader, error) {
 	}
 
 	if args.Files != nil {
-		body, contentType, err := prepareFilesBody(args.Files, args.Data)
+		prepare := prepareFilesBody
+		if args.Retry != nil {
+			// A piped body can only be read once, so req.GetBody would be
+			// nil and a retried request would fail; buffer it instead so
+			// it can be replayed between attempts.
+			prepare = prepareFilesBodyBuffered
+		}
+		body, contentType, err := prepare(args.Files, args.Data, args.Progress)
 		args.Headers["Content-type"] = contentType
 		return body, err
 	}
@@ -191,42 +307,6 @@ func prepareBody(args *RequestArguments) (io.Reader, error) {
 	return nil, nil
 }
 
-// prepareFilesBody prepares the body for a multipart/form-data request.
-// It returns body, contentType and error.
-func prepareFilesBody(files []FileField, data map[string]string) (io.Reader, string, error) {
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-
-	for _, file := range files {
-		fileWriter, err := writer.CreateFormFile(file.FieldName, file.FileName)
-		if err != nil {
-			return nil, "", fmt.Errorf("prepareFilesBody error: %w", err)
-		}
-
-		if _, err := io.Copy(fileWriter, file.FileContent); err != nil {
-			return nil, "", err
-		}
-
-		if err := file.FileContent.Close(); err != nil {
-			return nil, "", err
-		}
-	}
-
-	for key, value := range data {
-		err := writer.WriteField(key, value)
-		if err != nil {
-			return nil, "", fmt.Errorf("prepareFilesBody error: %w", err)
-		}
-	}
-
-	if err := writer.Close(); err != nil {
-		return nil, "", fmt.Errorf("prepareFilesBody error: %w", err)
-	}
-
-	contentType := writer.FormDataContentType()
-	return body, contentType, nil
-}
-
 // prepareDataBody prepares the body for a application/x-www-form-urlencoded request.
 func prepareDataBody(data map[string]string) (io.Reader, error) {
 	reader := strings.NewReader(encodeParams(data))
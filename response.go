@@ -0,0 +1,207 @@
+package requests4go
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+
+	"github.com/bitly/go-simplejson"
+)
+
+// Response wraps the *http.Response returned by sendRequest, exposing
+// convenience accessors for its body.
+type Response struct {
+	*http.Response
+
+	// content caches the body once it has been read by Content, Text,
+	// Json, JSONInto or XMLInto, so repeated calls don't re-read it.
+	content []byte
+}
+
+// NewResponse wraps resp as a *Response. It is typically called directly
+// with the return values of http.Client.Do, so a non-nil err is passed
+// through unchanged.
+func NewResponse(resp *http.Response, err error) (*Response, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &Response{Response: resp}, nil
+}
+
+// Ok reports whether the response status code is in the 2xx range.
+func (r *Response) Ok() bool {
+	return r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// Content reads and caches the full response body as bytes. Subsequent
+// calls return the cached content without reading the body again.
+func (r *Response) Content() ([]byte, error) {
+	if r.content != nil {
+		return r.content, nil
+	}
+
+	defer r.Body.Close()
+	content, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Response.Content error: %w", err)
+	}
+
+	r.content = content
+	r.Body = io.NopCloser(bytes.NewReader(content))
+	return r.content, nil
+}
+
+// Text returns the response body decoded as a string.
+func (r *Response) Text() (string, error) {
+	content, err := r.Content()
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// Json decodes the response body as JSON, returning it as a
+// *simplejson.Json for convenient dynamic access, e.g.
+// j.Get("field").String() on the returned j.
+func (r *Response) Json() (*simplejson.Json, error) {
+	content, err := r.Content()
+	if err != nil {
+		return nil, err
+	}
+
+	JSON, err := simplejson.NewJson(content)
+	if err != nil {
+		return nil, fmt.Errorf("Response.Json error: %w", err)
+	}
+	return JSON, nil
+}
+
+// JSONInto decodes the response body into v using the Consumer registered
+// for "application/json", regardless of what the response's own
+// Content-Type header says: the caller already told us to expect JSON,
+// so a mislabeled header (a common API quirk) must not change that.
+func (r *Response) JSONInto(v interface{}) error {
+	return r.decodeAs(AppJSON, v)
+}
+
+// XMLInto decodes the response body into v using the Consumer registered
+// for "application/xml", regardless of what the response's own
+// Content-Type header says.
+func (r *Response) XMLInto(v interface{}) error {
+	return r.decodeAs(AppXML, v)
+}
+
+// DecodeInto decodes the response body into v using the Consumer
+// registered for the response's own Content-Type header, for callers that
+// don't know the format ahead of time and want it picked for them.
+func (r *Response) DecodeInto(v interface{}) error {
+	mimeType := AppByteStream
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		if parsed, _, err := mime.ParseMediaType(ct); err == nil {
+			mimeType = parsed
+		}
+	}
+	return r.decodeAs(mimeType, v)
+}
+
+// Stream returns the raw response body for callers that want to read it
+// directly without buffering it into memory. It must not be combined with
+// Content, Text, Json, JSONInto or XMLInto on the same Response, since
+// those read and cache the body themselves.
+func (r *Response) Stream() io.ReadCloser {
+	return r.Body
+}
+
+// SaveTo streams the response body directly to the file at path without
+// loading it fully into memory, unlike Content. It closes the response
+// body once done.
+func (r *Response) SaveTo(path string) error {
+	defer r.Body.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("Response.SaveTo error: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, r.Body); err != nil {
+		return fmt.Errorf("Response.SaveTo error: %w", err)
+	}
+	return nil
+}
+
+// decodeAs feeds the cached body to the Consumer registered for mimeType.
+func (r *Response) decodeAs(mimeType string, v interface{}) error {
+	consumer, ok := consumers[mimeType]
+	if !ok {
+		return fmt.Errorf("decodeAs error: no consumer registered for %q", mimeType)
+	}
+
+	content, err := r.Content()
+	if err != nil {
+		return err
+	}
+
+	if err := consumer.Consume(bytes.NewReader(content), v); err != nil {
+		return fmt.Errorf("decodeAs error: %w", err)
+	}
+	return nil
+}
+
+// Consumer decodes a response body into v. Builtin implementations are
+// registered for "application/json", "application/xml" and the raw
+// bytestream fallback "application/octet-stream"; register more with
+// RegisterConsumer to support formats like protobuf, msgpack or NDJSON.
+type Consumer interface {
+	Consume(r io.Reader, v interface{}) error
+}
+
+// ConsumerFunc adapts a function to a Consumer.
+type ConsumerFunc func(r io.Reader, v interface{}) error
+
+// Consume calls f(r, v).
+func (f ConsumerFunc) Consume(r io.Reader, v interface{}) error {
+	return f(r, v)
+}
+
+// RegisterConsumer registers a Consumer for the given MIME type, replacing
+// any builtin or previously registered Consumer for that type.
+func RegisterConsumer(mimeType string, c Consumer) {
+	consumers[mimeType] = c
+}
+
+var consumers = map[string]Consumer{
+	AppJSON:       ConsumerFunc(consumeJSON),
+	AppXML:        ConsumerFunc(consumeXML),
+	"text/xml":    ConsumerFunc(consumeXML),
+	AppByteStream: ConsumerFunc(consumeBytes),
+}
+
+func consumeJSON(r io.Reader, v interface{}) error {
+	return json.NewDecoder(r).Decode(v)
+}
+
+func consumeXML(r io.Reader, v interface{}) error {
+	return xml.NewDecoder(r).Decode(v)
+}
+
+// consumeBytes implements the raw bytestream Consumer. v must be a
+// *[]byte, into which the full body is copied.
+func consumeBytes(r io.Reader, v interface{}) error {
+	buf, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("consumeBytes error: v must be *[]byte, got %T", v)
+	}
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	*buf = content
+	return nil
+}
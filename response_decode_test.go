@@ -0,0 +1,40 @@
+package requests4go
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestResponse(body, contentType string) *Response {
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{contentType}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+	r, _ := NewResponse(resp, nil)
+	return r
+}
+
+func TestJSONIntoIgnoresMislabeledContentType(t *testing.T) {
+	r := newTestResponse(`{"name":"kaiser"}`, "text/plain")
+
+	var v struct {
+		Name string `json:"name"`
+	}
+	assert.Nil(t, r.JSONInto(&v))
+	assert.Equal(t, "kaiser", v.Name)
+}
+
+func TestDecodeIntoUsesContentTypeHeader(t *testing.T) {
+	r := newTestResponse(`{"name":"kaiser"}`, AppJSON)
+
+	var v struct {
+		Name string `json:"name"`
+	}
+	assert.Nil(t, r.DecodeInto(&v))
+	assert.Equal(t, "kaiser", v.Name)
+}
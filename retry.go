@@ -0,0 +1,243 @@
+package requests4go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultRetriableStatusCodes are the status codes retried when
+// RetryPolicy.RetriableStatusCodes is empty.
+var DefaultRetriableStatusCodes = []int{
+	http.StatusRequestTimeout,
+	http.StatusTooManyRequests,
+	425, // Too Early
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryPolicy configures how sendRequest retries a request that fails with
+// a transient network error or a retriable status code.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first
+	// one. A value <= 1 disables retrying.
+	MaxAttempts int
+
+	// BackoffBase is the base duration used to compute the exponential
+	// backoff between attempts.
+	BackoffBase time.Duration
+
+	// BackoffCap caps the computed backoff duration.
+	BackoffCap time.Duration
+
+	// RetriableStatusCodes lists the HTTP status codes that should be
+	// retried. If empty, DefaultRetriableStatusCodes is used.
+	RetriableStatusCodes []int
+
+	// RetriableError reports whether err should be retried. If nil,
+	// IsRetriableError is used.
+	RetriableError func(error) bool
+
+	// RespectRetryAfter, when true, honors a Retry-After header returned by
+	// the server instead of the computed backoff.
+	RespectRetryAfter bool
+
+	// OnRetry, when set, is called before each retry attempt with the
+	// attempt number (starting at 1) and the response or error that
+	// triggered it.
+	OnRetry func(attempt int, resp *http.Response, err error)
+}
+
+// NewRetryPolicy returns a RetryPolicy with sane defaults: 3 attempts, a
+// 500ms backoff base capped at 10s, and Retry-After honored.
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:       3,
+		BackoffBase:       500 * time.Millisecond,
+		BackoffCap:        10 * time.Second,
+		RespectRetryAfter: true,
+	}
+}
+
+// IsRetriableError reports whether err looks like a transient network
+// error worth retrying, e.g. timeouts and connection resets.
+func IsRetriableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
+
+func (p *RetryPolicy) isRetriableStatus(code int) bool {
+	codes := p.RetriableStatusCodes
+	if len(codes) == 0 {
+		codes = DefaultRetriableStatusCodes
+	}
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *RetryPolicy) isRetriableError(err error) bool {
+	if p.RetriableError != nil {
+		return p.RetriableError(err)
+	}
+	return IsRetriableError(err)
+}
+
+// backoffDuration computes the exponential backoff with full jitter for the
+// given attempt (0-indexed):
+//
+//	sleep = rand(0, min(cap, base * 2^attempt))
+func (p *RetryPolicy) backoffDuration(attempt int) time.Duration {
+	cap := p.BackoffCap
+	if cap <= 0 {
+		cap = 10 * time.Second
+	}
+	base := p.BackoffBase
+	if base <= 0 {
+		base = 500 * time.Millisecond
+	}
+	upper := time.Duration(math.Min(float64(cap), float64(base)*math.Pow(2, float64(attempt))))
+	if upper <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(upper)))
+}
+
+// retryAfterDuration parses the Retry-After header of resp, returning the
+// duration to wait and whether it was present and valid.
+func retryAfterDuration(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if when, err := http.ParseTime(v); err == nil {
+		return time.Until(when), true
+	}
+	return 0, false
+}
+
+// sendRequestWithRetry drives req through args.Client, replaying the
+// request body via req.GetBody and resetting cookies between attempts,
+// until it succeeds, exhausts args.Retry.MaxAttempts, or runs past the
+// total deadline derived from args.Timeout. Unlike http.Client.Timeout,
+// which is a per-Do-call deadline, this deadline is shared across every
+// attempt and its backoff waits.
+func sendRequestWithRetry(req *http.Request, args *RequestArguments) (*Response, error) {
+	policy := args.Retry
+	handler := buildHandler(args)
+
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+
+	ctx := args.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if args.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, args.Timeout)
+		defer cancel()
+	}
+	req = req.WithContext(ctx)
+
+	// A request with no body (e.g. a GET) has a nil GetBody too, since
+	// http.NewRequest only populates it when there's an actual body to
+	// replay; that's not a replayability problem, so only requests that
+	// started out with a body need to pass the GetBody check below.
+	hasBody := req.Body != nil
+
+	var httpResp *http.Response
+	var err error
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			if hasBody {
+				if req.GetBody == nil {
+					return nil, fmt.Errorf("sendRequestWithRetry error: request body is not replayable")
+				}
+				body, gbErr := req.GetBody()
+				if gbErr != nil {
+					return nil, fmt.Errorf("sendRequestWithRetry error: %w", gbErr)
+				}
+				req.Body = body
+			}
+			resetRequestCookies(args, req)
+		}
+
+		httpResp, err = handler(req)
+
+		if err == nil && !policy.isRetriableStatus(httpResp.StatusCode) {
+			return NewResponse(httpResp, nil)
+		}
+		if err != nil && !policy.isRetriableError(err) {
+			return nil, err
+		}
+		if attempt == maxAttempts-1 {
+			return NewResponse(httpResp, err)
+		}
+
+		wait := policy.backoffDuration(attempt)
+		if policy.RespectRetryAfter {
+			if ra, ok := retryAfterDuration(httpResp); ok {
+				wait = ra
+			}
+		}
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt+1, httpResp, err)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if httpResp != nil {
+				_ = httpResp.Body.Close()
+			}
+			return nil, fmt.Errorf("sendRequestWithRetry error: retry deadline exceeded: %w", ctx.Err())
+		case <-timer.C:
+		}
+
+		// We're committed to retrying: only now close the previous
+		// attempt's body, so a response returned above is never handed
+		// back with its body already closed.
+		if httpResp != nil {
+			_ = httpResp.Body.Close()
+		}
+	}
+
+	return NewResponse(httpResp, err)
+}
+
+// resetRequestCookies clears any cookie header set on req by a previous
+// attempt and re-applies args.Cookies from the jar, so a retried request
+// doesn't replay a stale Cookie header.
+func resetRequestCookies(args *RequestArguments, req *http.Request) {
+	req.Header.Del("Cookie")
+	prepareCookies(args, req)
+}
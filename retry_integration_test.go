@@ -0,0 +1,54 @@
+package requests4go
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendRequestWithRetryRecoversAfterTransientFailure(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	args := NewRequestArguments()
+	args.Retry = &RetryPolicy{MaxAttempts: 2, BackoffBase: time.Millisecond, BackoffCap: time.Millisecond}
+
+	resp, err := Get(srv.URL, args)
+	assert.Nil(t, err)
+	assert.True(t, resp.Ok())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestSendRequestWithRetryAbortsOnContextCancelled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	args := NewRequestArguments()
+	args.Context = ctx
+	args.Retry = &RetryPolicy{MaxAttempts: 5, BackoffBase: 50 * time.Millisecond, BackoffCap: 50 * time.Millisecond}
+
+	_, err := Get(srv.URL, args)
+	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, context.Canceled))
+}
@@ -0,0 +1,61 @@
+package requests4go
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoffDuration(t *testing.T) {
+	p := &RetryPolicy{BackoffBase: time.Second, BackoffCap: 4 * time.Second}
+
+	tests := []struct {
+		attempt int
+		upper   time.Duration
+	}{
+		{0, time.Second},
+		{1, 2 * time.Second},
+		{2, 4 * time.Second},
+		{3, 4 * time.Second}, // capped
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			d := p.backoffDuration(tt.attempt)
+			assert.GreaterOrEqual(t, d, time.Duration(0))
+			assert.LessOrEqual(t, d, tt.upper)
+		}
+	}
+}
+
+func TestIsRetriableStatus(t *testing.T) {
+	p := &RetryPolicy{}
+	assert.True(t, p.isRetriableStatus(http.StatusTooManyRequests))
+	assert.True(t, p.isRetriableStatus(http.StatusBadGateway))
+	assert.False(t, p.isRetriableStatus(http.StatusOK))
+	assert.False(t, p.isRetriableStatus(http.StatusNotFound))
+}
+
+func TestIsRetriableStatusCustom(t *testing.T) {
+	p := &RetryPolicy{RetriableStatusCodes: []int{http.StatusNotFound}}
+	assert.True(t, p.isRetriableStatus(http.StatusNotFound))
+	assert.False(t, p.isRetriableStatus(http.StatusTooManyRequests))
+}
+
+func TestRetryAfterDuration(t *testing.T) {
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"2"}}}
+	d, ok := retryAfterDuration(resp)
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Second, d)
+
+	resp = &http.Response{Header: http.Header{}}
+	_, ok = retryAfterDuration(resp)
+	assert.False(t, ok)
+
+	resp = &http.Response{Header: http.Header{"Retry-After": []string{time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)}}}
+	d, ok = retryAfterDuration(resp)
+	assert.True(t, ok)
+	assert.Greater(t, d, time.Duration(0))
+}
@@ -0,0 +1,112 @@
+package requests4go
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Session represents a session that persists state, such as cookies held
+// in its Client's cookie jar, across multiple requests.
+type Session struct {
+	// Client is the *http.Client shared by every request made through the
+	// session, keeping cookies, redirects and TLS config consistent
+	// across calls.
+	Client *http.Client
+
+	middlewares  []Middleware
+	allowedHosts map[string]struct{}
+	knownURLs    []*url.URL
+}
+
+// NewSession returns a new Session. If client is nil, a default
+// *http.Client with a cookie jar is used.
+func NewSession(client *http.Client) *Session {
+	if client == nil {
+		client = &http.Client{Jar: setDefaultJar()}
+	}
+	return &Session{Client: client}
+}
+
+// Use appends middlewares to the session's chain. Middlewares already
+// registered on the session wrap the ones passed here and run before
+// them; among those passed, the first is the outermost one.
+func (s *Session) Use(middlewares ...Middleware) {
+	s.middlewares = append(s.middlewares, middlewares...)
+}
+
+// do sends a request of the given method through the session's Client and
+// middleware chain, applying it on top of args.
+func (s *Session) do(method, reqUrl string, args *RequestArguments) (*Response, error) {
+	if args == nil {
+		args = NewRequestArguments()
+	}
+	args.Client = s.Client
+	args.Middlewares = append(append([]Middleware{}, s.middlewares...), args.Middlewares...)
+	args.AllowedHosts = s.allowedHosts
+
+	if u, err := url.Parse(reqUrl); err == nil {
+		s.trackURL(u)
+	}
+
+	return sendRequest(method, reqUrl, args)
+}
+
+// trackURL records u's host so SaveCookies knows which URLs to read the
+// jar's cookies from. It is a no-op if the host is already known.
+func (s *Session) trackURL(u *url.URL) {
+	for _, known := range s.knownURLs {
+		if known.Host == u.Host {
+			return
+		}
+	}
+	s.knownURLs = append(s.knownURLs, u)
+}
+
+// Get sends a GET request through the session.
+func (s *Session) Get(reqUrl string, args *RequestArguments) (*Response, error) {
+	return s.do(http.MethodGet, reqUrl, args)
+}
+
+// Post sends a POST request through the session.
+func (s *Session) Post(reqUrl string, args *RequestArguments) (*Response, error) {
+	return s.do(http.MethodPost, reqUrl, args)
+}
+
+// Put sends a PUT request through the session.
+func (s *Session) Put(reqUrl string, args *RequestArguments) (*Response, error) {
+	return s.do(http.MethodPut, reqUrl, args)
+}
+
+// Delete sends a DELETE request through the session.
+func (s *Session) Delete(reqUrl string, args *RequestArguments) (*Response, error) {
+	return s.do(http.MethodDelete, reqUrl, args)
+}
+
+// Patch sends a PATCH request through the session.
+func (s *Session) Patch(reqUrl string, args *RequestArguments) (*Response, error) {
+	return s.do(http.MethodPatch, reqUrl, args)
+}
+
+// Head sends a HEAD request through the session.
+func (s *Session) Head(reqUrl string, args *RequestArguments) (*Response, error) {
+	return s.do(http.MethodHead, reqUrl, args)
+}
+
+// GetWithContext sends a GET request through the session, bound to ctx.
+func (s *Session) GetWithContext(ctx context.Context, reqUrl string, args *RequestArguments) (*Response, error) {
+	if args == nil {
+		args = NewRequestArguments()
+	}
+	args.Context = ctx
+	return s.do(http.MethodGet, reqUrl, args)
+}
+
+// PostWithContext sends a POST request through the session, bound to ctx.
+func (s *Session) PostWithContext(ctx context.Context, reqUrl string, args *RequestArguments) (*Response, error) {
+	if args == nil {
+		args = NewRequestArguments()
+	}
+	args.Context = ctx
+	return s.do(http.MethodPost, reqUrl, args)
+}
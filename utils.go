@@ -19,4 +19,39 @@ type M = map[string]string
 const (
 	// AppJSON is a shortcut for "application/json"
 	AppJSON = "application/json"
+
+	// AppXML is a shortcut for "application/xml"
+	AppXML = "application/xml"
+
+	// AppByteStream is a shortcut for "application/octet-stream"
+	AppByteStream = "application/octet-stream"
 )
+
+// defaultRedirectLimit is the RedirectLimit NewRequestArguments starts out
+// with. sendRequest does not apply it to caller-built arguments, where a
+// zero RedirectLimit means no limit.
+const defaultRedirectLimit = 10
+
+// defaultJsonType is the Content-Type applied to a request body built from
+// RequestArguments.Json.
+const defaultJsonType = AppJSON
+
+// defaultContentType is the Content-Type applied to a request body built
+// from RequestArguments.Data.
+const defaultContentType = "application/x-www-form-urlencoded"
+
+// defaultHeaders are the headers a new RequestArguments starts out with.
+var defaultHeaders = map[string]string{
+	"User-Agent": "requests4go",
+}
+
+// cloneDefaultHeaders returns a fresh copy of defaultHeaders, so a request
+// that mutates its own Headers (e.g. prepareBody setting Content-Type)
+// never mutates the shared default map.
+func cloneDefaultHeaders() map[string]string {
+	h := make(map[string]string, len(defaultHeaders))
+	for k, v := range defaultHeaders {
+		h[k] = v
+	}
+	return h
+}